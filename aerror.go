@@ -3,6 +3,7 @@
 package aerrors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -17,10 +18,17 @@ type AsyncError struct {
 	stopCh       chan struct{}
 	baseError    error
 	handler      ErrorHandler
+	handlerV2    ErrorHandlerV2
 	errorChanLen int
-	errorChan    chan error
+	errorChans   map[Severity]chan error
+	fatalHook    func(error)
 	closed       bool
 	running      bool
+	panicStack   bool
+	dedup        *dedupSuppressor
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wake         chan struct{}
 	mu           sync.Mutex
 	wg           sync.WaitGroup
 }
@@ -52,10 +60,14 @@ type ErrorHandler interface {
 //
 // See "aerrors.With*" to modify the default behavior.
 func New(opts ...Option) *AsyncError {
+	ctx, cancel := context.WithCancel(context.Background())
 	a := &AsyncError{
-		stopCh:    make(chan struct{}),
-		logger:    DefaultLogger,
-		errorChan: make(chan error, defaultErrorChanLen),
+		stopCh:     make(chan struct{}),
+		logger:     DefaultLogger,
+		errorChans: newSeverityChans(defaultErrorChanLen),
+		ctx:        ctx,
+		cancel:     cancel,
+		wake:       make(chan struct{}, 1),
 	}
 	for _, opt := range opts {
 		opt(a)
@@ -79,29 +91,18 @@ func (e *AsyncError) IsRunning() bool {
 	return e.running
 }
 
-// Add puts your error in queue to handle. It blocks if we reached chan length
+// Add puts your error in queue to handle, at the default severity
+// (SeverityError). It blocks if we reached chan length. See
+// AddWithSeverity to pick a different severity.
 func (e *AsyncError) Add(err error) error {
-	if e.IsClosed() {
-
-		return errors.New("aerrors: can't add error to closed chan")
-	}
-
-	e.wg.Add(1)
-	e.errorChan <- err
-
-	return nil
+	return e.AddWithSeverity(err, defaultSeverity)
 }
 
-// AddAsync puts your error in queue in goroutine. It not blocks when we reached chan length
+// AddAsync puts your error in queue in goroutine, at the default severity
+// (SeverityError). It not blocks when we reached chan length. See
+// AddAsyncWithSeverity to pick a different severity.
 func (e *AsyncError) AddAsync(err error) error {
-	if e.IsClosed() {
-
-		return errors.New("aerrors: can't async add error to closed chan")
-	}
-	e.wg.Add(1)
-	go func() { e.errorChan <- err }()
-
-	return nil
+	return e.AddAsyncWithSeverity(err, defaultSeverity)
 }
 
 // Stop handle errors
@@ -121,24 +122,57 @@ func (e *AsyncError) stop() {
 	e.stopCh <- struct{}{}
 }
 
+// deliver enqueues err at severity s, returning false instead of sending if
+// the aerror is already closed. It holds the same mutex as Close, so a
+// concurrent Close can't race between the closed-check and the channel
+// send the way a plain IsClosed()-then-send would. It also wakes start()
+// if it's idle, so a fresh arrival doesn't wait on a stale blocking select.
+func (e *AsyncError) deliver(err error, s Severity) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return false
+	}
+
+	e.wg.Add(1)
+	e.errorChans[s] <- err
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
 // Close the aerror gracefully. It waits to handle all errors from queue.
-// You can't use it after closing and have to create a new one.
+// You can't use it after closing and have to create a new one. The mutex
+// is released while waiting for the queue to drain, so a handler call in
+// flight (however slow) never blocks other methods like IsClosed or Add.
 func (e *AsyncError) Close() {
-	if e.IsClosed() {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
 		return
 	}
-
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	e.closed = true
-	if e.running {
+	running := e.running
+	e.mu.Unlock()
+
+	if running {
 		e.wg.Wait()
-		e.stop()
+		e.Stop()
 	}
 
-	close(e.errorChan)
+	e.cancel()
+
+	e.mu.Lock()
+	for _, ch := range e.errorChans {
+		close(ch)
+	}
 	close(e.stopCh)
+	e.mu.Unlock()
 }
 
 // StartHandle starts handling errors
@@ -157,22 +191,51 @@ func (e *AsyncError) StartHandle() error {
 	return nil
 }
 
+// start drains errorChans in priority order (highest severity first) so a
+// backlog of low-severity errors never delays a fatal one. The blocking
+// select below only waits for *some* channel to have become non-empty; it
+// never consumes a value itself. If it picked one of the errorChans
+// directly instead, Go's select chooses uniformly among whatever cases
+// are ready at that instant, which could hand it a debug error even
+// though a fatal one arrived in the same instant. Waking up and then
+// re-running dequeue()'s deterministic, priority-ordered scan avoids that.
 func (e *AsyncError) start() {
 	for {
-		select {
-		case newError := <-e.errorChan:
-			if newError != nil {
-				e.handle(newError)
-				e.wg.Done()
-			}
+		if newError, ok := e.dequeue(); ok {
+			e.consume(newError)
+			continue
+		}
 
+		select {
 		case <-e.stopCh:
 			e.logger.Info("aerrors: stop")
 			return
+		case <-e.wake:
 		}
 	}
 }
 
+// dequeue returns the highest-severity error currently buffered across all
+// chans, without blocking. ok is false when none is ready.
+func (e *AsyncError) dequeue() (error, bool) {
+	for _, s := range severityOrder {
+		select {
+		case err := <-e.errorChans[s]:
+			return err, true
+		default:
+		}
+	}
+
+	return nil, false
+}
+
+func (e *AsyncError) consume(err error) {
+	if err != nil {
+		e.handle(err)
+		e.wg.Done()
+	}
+}
+
 // Wrap your error
 func Wrap(errp *error, format string, args ...interface{}) {
 	if errp != nil && *errp != nil {
@@ -181,12 +244,12 @@ func Wrap(errp *error, format string, args ...interface{}) {
 	}
 }
 
-// PanicToError recovers panic and creates an error from it
+// PanicToError recovers panic and creates a *PanicError from it. When
+// WithPanicStack(true) was set, the stack trace at the point of recovery
+// is captured alongside the recovered value.
 func (e *AsyncError) PanicToError() {
 	if p := recover(); p != nil {
-		err := fmt.Errorf("%v", p)
-		Wrap(&err, "recoverToError()")
-		_ = e.Add(err)
+		_ = e.Add(panicError(p, e.panicStack))
 	}
 }
 
@@ -198,15 +261,28 @@ func (e *AsyncError) Go(f func()) {
 	}()
 }
 
+// handle dispatches err to the configured handler synchronously, so a
+// backlog is handled strictly in the priority order start() dequeues it in.
+// handlerV2 is given e.ctx. Close cancels it only after the queue has
+// drained; CloseContext also cancels it when its own ctx is done, so a
+// handler that respects context cancellation can't block that shutdown
+// forever.
 func (e *AsyncError) handle(err error) {
-	err = fmt.Errorf("%w: %v", e.baseError, err)
+	if e.baseError != nil {
+		err = fmt.Errorf("%w: %v", e.baseError, err)
+	} else {
+		err = fmt.Errorf("%w", err)
+	}
 	Wrap(&err, "HandleError()")
 
-	if e.handler != nil {
-		go func() {
-			e.handler.HandleError(err)
-		}()
-	} else {
+	switch {
+	case e.handlerV2 != nil:
+		if handleErr := e.handlerV2.HandleError(e.ctx, err); handleErr != nil {
+			e.logger.Error(handleErr, "aerror: handler returned an error")
+		}
+	case e.handler != nil:
+		e.handler.HandleError(err)
+	default:
 		e.logger.Error(err, "aerror handled error")
 	}
 }
@@ -48,7 +48,7 @@ func TestErrorHandler(t *testing.T) {
 
 	aerror.Add(errors.New("testing handler"))
 
-	if len(aerror.errorChan) != 1 {
+	if len(aerror.errorChans[SeverityError]) != 1 {
 		t.Error("expected chan len of 1")
 	}
 
@@ -57,7 +57,7 @@ func TestErrorHandler(t *testing.T) {
 		t.Error("expected to have an error")
 	}
 
-	if len(aerror.errorChan) != 0 {
+	if len(aerror.errorChans[SeverityError]) != 0 {
 		t.Error("chan is not empty")
 	}
 }
@@ -72,8 +72,8 @@ func TestErrorHandlerMultipleAdd(t *testing.T) {
 	aerror.Add(errors.New("testing handler 2"))
 	aerror.Add(errors.New("testing handler 3"))
 
-	if len(aerror.errorChan) != 3 {
-		t.Error("expected chan len of 3", len(aerror.errorChan))
+	if len(aerror.errorChans[SeverityError]) != 3 {
+		t.Error("expected chan len of 3", len(aerror.errorChans[SeverityError]))
 	}
 
 	time.Sleep(sleepTime)
@@ -81,7 +81,7 @@ func TestErrorHandlerMultipleAdd(t *testing.T) {
 		t.Error("expected to have 3 errors")
 	}
 
-	if len(aerror.errorChan) != 0 {
+	if len(aerror.errorChans[SeverityError]) != 0 {
 		t.Error("chan is not empty")
 	}
 }
@@ -94,7 +94,7 @@ func TestWithBaseError(t *testing.T) {
 
 	aerror.Add(errors.New("testing handler with base error"))
 
-	if len(aerror.errorChan) != 1 {
+	if len(aerror.errorChans[SeverityError]) != 1 {
 		t.Error("expected chan len of 1")
 	}
 
@@ -103,7 +103,7 @@ func TestWithBaseError(t *testing.T) {
 		t.Error("expected to have an errors")
 	}
 
-	if len(aerror.errorChan) != 0 {
+	if len(aerror.errorChans[SeverityError]) != 0 {
 		t.Error("chan is not empty")
 	}
 
@@ -169,7 +169,7 @@ func TestOverflowErrorChan(t *testing.T) {
 	aerror.AddAsync(errors.New("testing handler 4"))
 	aerror.AddAsync(errors.New("testing handler 5"))
 
-	if len(aerror.errorChan) != 2 {
+	if len(aerror.errorChans[SeverityError]) != 2 {
 		t.Error("expected to have 2 errors in chan")
 	}
 
@@ -213,7 +213,7 @@ func TestCloseStartedAerror(t *testing.T) {
 		t.Error(err)
 	}
 
-	if len(aerror.errorChan) != 2 {
+	if len(aerror.errorChans[SeverityError]) != 2 {
 		t.Error("expected to have 2 errors in chan")
 	}
 
@@ -277,6 +277,51 @@ func TestWorkWithClosedAerror(t *testing.T) {
 	}
 }
 
+// TestCloseDoesNotWedgeOnSlowHandler guards against Close holding its
+// mutex across a slow handler call, which would wedge every other method
+// on the instance until the handler happened to return.
+func TestCloseDoesNotWedgeOnSlowHandler(t *testing.T) {
+	unblock := make(chan struct{})
+	handler := ErrorHandlerFunc(func(err error) {
+		<-unblock
+	})
+
+	aerror := New(WithHandler(handler))
+	aerror.StartHandle()
+
+	if err := aerror.Add(errors.New("slow")); err != nil {
+		t.Fatalf("unexpected error from Add: %v", err)
+	}
+	time.Sleep(sleepTime)
+
+	closeDone := make(chan struct{})
+	go func() {
+		aerror.Close()
+		close(closeDone)
+	}()
+	time.Sleep(sleepTime)
+
+	isClosedDone := make(chan struct{})
+	go func() {
+		aerror.IsClosed()
+		close(isClosedDone)
+	}()
+
+	select {
+	case <-isClosedDone:
+	case <-time.After(time.Second):
+		t.Fatal("IsClosed was wedged by a slow handler call held under Close's mutex")
+	}
+
+	close(unblock)
+
+	select {
+	case <-closeDone:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return after the handler unblocked")
+	}
+}
+
 func TestCloseClosedAerror(t *testing.T) {
 	aerror := New(WithHandler(&th), WithBaseError(&testErr), WithErrorChanLen(2))
 	defer th.Reset()
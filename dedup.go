@@ -0,0 +1,99 @@
+package aerrors
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DuplicateError wraps an error that arrived more than once within a dedup
+// window, carrying how many times it was seen and when.
+type DuplicateError struct {
+	Err       error
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%s (repeated %d times over %s)", e.Err, e.Count, e.LastSeen.Sub(e.FirstSeen))
+}
+
+// Unwrap exposes the first occurrence so errors.Is/errors.As keep working
+// against it.
+func (e *DuplicateError) Unwrap() error {
+	return e.Err
+}
+
+type dedupEntry struct {
+	err      error
+	severity Severity
+	count    int
+	first    time.Time
+	last     time.Time
+}
+
+// dedupSuppressor lets the first error of each key (from keyFn) through and
+// folds repeats arriving within window into a single DuplicateError, which
+// is enqueued once the window elapses. It mirrors the duplicate-suppression
+// idea behind singleflight, but applied to the error stream instead of
+// function calls.
+type dedupSuppressor struct {
+	window  time.Duration
+	keyFn   func(error) string
+	enqueue func(error, Severity)
+
+	mu       sync.Mutex
+	inFlight map[string]*dedupEntry
+}
+
+func newDedupSuppressor(window time.Duration, keyFn func(error) string, enqueue func(error, Severity)) *dedupSuppressor {
+	return &dedupSuppressor{
+		window:   window,
+		keyFn:    keyFn,
+		enqueue:  enqueue,
+		inFlight: make(map[string]*dedupEntry),
+	}
+}
+
+// shouldEnqueue reports whether err is the first sighting of its key and
+// should be enqueued as usual. A duplicate arriving while the key is still
+// in-flight is folded into the existing entry and returns false.
+func (d *dedupSuppressor) shouldEnqueue(err error, s Severity) bool {
+	key := d.keyFn(err)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if entry, ok := d.inFlight[key]; ok {
+		entry.count++
+		entry.last = time.Now()
+		return false
+	}
+
+	now := time.Now()
+	d.inFlight[key] = &dedupEntry{err: err, severity: s, count: 1, first: now, last: now}
+	time.AfterFunc(d.window, func() { d.flush(key) })
+
+	return true
+}
+
+func (d *dedupSuppressor) flush(key string) {
+	d.mu.Lock()
+	entry, ok := d.inFlight[key]
+	if ok {
+		delete(d.inFlight, key)
+	}
+	d.mu.Unlock()
+
+	if !ok || entry.count <= 1 {
+		return
+	}
+
+	d.enqueue(&DuplicateError{
+		Err:       entry.err,
+		Count:     entry.count,
+		FirstSeen: entry.first,
+		LastSeen:  entry.last,
+	}, entry.severity)
+}
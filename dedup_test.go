@@ -0,0 +1,59 @@
+package aerrors
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDedupCollapsesRepeatedErrors(t *testing.T) {
+	aerror := New(WithHandler(&th), WithDedup(20*time.Millisecond, nil))
+	aerror.StartHandle()
+	defer aerror.Stop()
+	defer th.Reset()
+
+	for i := 0; i < 3; i++ {
+		aerror.Add(errors.New("flaky write"))
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if len(th.errs) != 1 {
+		t.Fatalf("expected only the first occurrence to be handled immediately, got %d", len(th.errs))
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if len(th.errs) != 2 {
+		t.Fatalf("expected a summary error once the dedup window elapsed, got %d", len(th.errs))
+	}
+
+	var dup *DuplicateError
+	if !errors.As(th.errs[1], &dup) {
+		t.Fatal("expected second error to be a *DuplicateError")
+	}
+
+	if dup.Count != 3 {
+		t.Errorf("expected count of 3, got %d", dup.Count)
+	}
+}
+
+func TestDedupDistinctKeysNotCollapsed(t *testing.T) {
+	aerror := New(WithHandler(&th), WithDedup(20*time.Millisecond, nil))
+	aerror.StartHandle()
+	defer aerror.Stop()
+	defer th.Reset()
+
+	aerror.Add(errors.New("error a"))
+	aerror.Add(errors.New("error b"))
+	time.Sleep(5 * time.Millisecond)
+
+	if len(th.errs) != 2 {
+		t.Fatalf("expected both distinct errors to be handled, got %d", len(th.errs))
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if len(th.errs) != 2 {
+		t.Fatalf("expected no summary for errors seen only once, got %d", len(th.errs))
+	}
+}
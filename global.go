@@ -1,21 +1,89 @@
 package aerrors
 
-import "errors"
+import "sync/atomic"
 
-var gAerror *AsyncError
-var errHasInitialized = errors.New("error: try to initialize global aerror while other is running")
+// delegator holds the currently active global AsyncError and lets it be
+// swapped at runtime without an "already initialized" foot-gun.
+type delegator struct {
+	current atomic.Value // holds *AsyncError
+}
+
+func (d *delegator) load() *AsyncError {
+	a, _ := d.current.Load().(*AsyncError)
+	return a
+}
 
-// Init a single globally async error handler
-func Init(opts ...Option) error {
-	if gAerror != nil && gAerror.IsRunning() {
-		return errHasInitialized
+var global delegator
+
+// SetGlobal atomically swaps the active global AsyncError. Any previously
+// active delegate is drained and closed in the background so errors it
+// already queued aren't lost.
+func SetGlobal(a *AsyncError) {
+	prev, _ := global.current.Swap(a).(*AsyncError)
+
+	if prev != nil {
+		go prev.Close()
 	}
-	a := New(opts...)
-	gAerror = a
-	return nil
 }
 
-// Get initialized global async error handler
+// Get returns the currently active global AsyncError, or nil if SetGlobal
+// hasn't been called yet.
 func Get() *AsyncError {
-	return gAerror
+	return global.load()
+}
+
+// Add forwards err to the current global delegate. Before SetGlobal is
+// ever called there is no delegate yet, so err is logged with DefaultLogger
+// instead of being dropped.
+func Add(err error) error {
+	a := global.load()
+	if a == nil {
+		DefaultLogger.Error(err, "aerrors: no global handler set")
+		return nil
+	}
+
+	return a.Add(err)
+}
+
+// AddAsync forwards err to the current global delegate asynchronously. See
+// Add for the behavior before a delegate has been set.
+func AddAsync(err error) error {
+	a := global.load()
+	if a == nil {
+		DefaultLogger.Error(err, "aerrors: no global handler set")
+		return nil
+	}
+
+	return a.AddAsync(err)
+}
+
+// Go runs f in a panic-safe goroutine using the current global delegate. If
+// no delegate has been set yet, f still runs, but a panic falls through to
+// PanicToError's default stderr logging.
+func Go(f func()) {
+	a := global.load()
+	if a == nil {
+		go func() {
+			defer PanicToError()
+			f()
+		}()
+		return
+	}
+
+	a.Go(f)
+}
+
+// PanicToError recovers a panic and forwards the resulting *PanicError to
+// the current global delegate, or logs it with DefaultLogger if none has
+// been set yet.
+func PanicToError() {
+	if p := recover(); p != nil {
+		a := global.load()
+		if a == nil {
+			DefaultLogger.Error(panicError(p, false), "aerrors: no global handler set")
+			return
+		}
+
+		_ = a.Add(panicError(p, a.panicStack))
+	}
 }
@@ -0,0 +1,53 @@
+package aerrors
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetGlobalForwardsErrors(t *testing.T) {
+	a := New(WithHandler(&th))
+	a.StartHandle()
+	SetGlobal(a)
+	defer SetGlobal(nil)
+	defer th.Reset()
+
+	Add(errors.New("via global Add"))
+	AddAsync(errors.New("via global AddAsync"))
+	time.Sleep(sleepTime)
+
+	if len(th.errs) != 2 {
+		t.Fatalf("expected both errors forwarded to the delegate, got %d", len(th.errs))
+	}
+}
+
+func TestSetGlobalHotSwapClosesPrevious(t *testing.T) {
+	first := New(WithHandler(&th))
+	first.StartHandle()
+	SetGlobal(first)
+
+	second := New(WithHandler(&th))
+	second.StartHandle()
+	SetGlobal(second)
+	defer SetGlobal(nil)
+	defer th.Reset()
+
+	time.Sleep(sleepTime)
+
+	if !first.IsClosed() {
+		t.Error("expected the previous delegate to be closed after a hot-swap")
+	}
+
+	if Get() != second {
+		t.Error("expected Get to return the newly active delegate")
+	}
+}
+
+func TestGlobalAddWithoutDelegateDoesNotPanic(t *testing.T) {
+	SetGlobal(nil)
+
+	if err := Add(errors.New("no delegate yet")); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package aerrors
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// Run starts handling errors and blocks until ctx is cancelled or one of
+// the given signals fires, then drains the queue and closes the aerror.
+// It lets callers embed an AsyncError into a service's lifecycle without
+// hand-rolling the start/stop/close/wait dance themselves.
+func (e *AsyncError) Run(ctx context.Context, sig ...os.Signal) error {
+	if err := e.StartHandle(); err != nil {
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	if len(sig) > 0 {
+		signal.Notify(sigCh, sig...)
+		defer signal.Stop(sigCh)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-sigCh:
+	}
+
+	e.Close()
+
+	return nil
+}
+
+// CloseContext closes the aerror like Close, but bounds how long it waits
+// for the queue to drain. If draining doesn't finish before ctx is done, it
+// cancels the aerror's internal context — which an ErrorHandlerV2 that
+// respects context cancellation (e.g. RetryHandler) observes and aborts on
+// — and returns ctx.Err(); the drain itself keeps running in the
+// background so Close can still finish once the handler unblocks.
+func (e *AsyncError) CloseContext(ctx context.Context) error {
+	if e.IsClosed() {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		e.cancel()
+		return ctx.Err()
+	}
+}
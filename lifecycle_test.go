@@ -0,0 +1,104 @@
+package aerrors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunStopsOnContextCancel(t *testing.T) {
+	aerror := New(WithHandler(&th))
+	defer th.Reset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() { done <- aerror.Run(ctx) }()
+
+	time.Sleep(sleepTime)
+	aerror.Add(errors.New("before shutdown"))
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected Run to return nil, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after context cancellation")
+	}
+
+	if !aerror.IsClosed() {
+		t.Error("expected aerror to be closed after Run returns")
+	}
+}
+
+func TestCloseContextReturnsErrOnTimeout(t *testing.T) {
+	aerror := New(WithHandler(&th), WithErrorChanLen(1))
+	defer th.Reset()
+
+	// Simulate a drain that never finishes: mark it running with an
+	// outstanding wg count that nothing will ever complete.
+	aerror.wg.Add(1)
+	aerror.mu.Lock()
+	aerror.running = true
+	aerror.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := aerror.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	aerror.wg.Done()
+}
+
+func TestCloseContextSucceedsWhenAlreadyClosed(t *testing.T) {
+	aerror := New(WithHandler(&th))
+	defer th.Reset()
+
+	aerror.Close()
+
+	if err := aerror.CloseContext(context.Background()); err != nil {
+		t.Errorf("expected nil error for already-closed aerror, got %v", err)
+	}
+}
+
+// blockingHandlerV2 only returns once its ctx is cancelled, simulating a
+// RetryHandler stuck waiting out its backoff.
+type blockingHandlerV2 struct{}
+
+func (blockingHandlerV2) HandleError(ctx context.Context, _ error) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCloseContextCancelsInFlightHandler(t *testing.T) {
+	aerror := New(WithHandlerV2(blockingHandlerV2{}))
+	aerror.StartHandle()
+	aerror.Add(errors.New("stuck handler"))
+
+	// Give start() a moment to dequeue the error and block inside handle().
+	time.Sleep(sleepTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := aerror.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// CloseContext cancels the aerror's internal context on timeout, which
+	// should unblock the handler and let the background Close get past the
+	// drain and stop handling. IsClosed alone can't show that: Close marks
+	// the aerror closed before it starts draining.
+	deadline := time.Now().Add(time.Second)
+	for aerror.IsRunning() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the background Close to stop handling once the handler unblocked")
+		}
+		time.Sleep(sleepTime)
+	}
+}
@@ -0,0 +1,24 @@
+package aerrors
+
+import "log"
+
+// Logger is the logging interface AsyncError uses to report internal
+// lifecycle events and, absent an ErrorHandler, the errors it handles.
+type Logger interface {
+	Info(args ...interface{})
+	Error(err error, args ...interface{})
+}
+
+// printfLogger is the default Logger, backed by the standard log package.
+type printfLogger struct{}
+
+func (printfLogger) Info(args ...interface{}) {
+	log.Println(args...)
+}
+
+func (printfLogger) Error(err error, args ...interface{}) {
+	log.Println(append(args, err)...)
+}
+
+// DefaultLogger is used wherever no Logger is supplied via WithLogger.
+var DefaultLogger Logger = printfLogger{}
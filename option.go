@@ -1,5 +1,7 @@
 package aerrors
 
+import "time"
+
 // Option represents a modification to the default behavior of a serro.
 type Option func(s *AsyncError)
 
@@ -24,9 +26,66 @@ func WithHandler(h ErrorHandler) Option {
 	}
 }
 
-// WithErrorChanLen sets the error chan length.
+// WithHandlerV2 uses the provided error handler. Unlike WithHandler, its
+// HandleError may itself fail (e.g. RetryHandler wrapping a flaky sink);
+// when both are set, the V2 handler takes priority.
+func WithHandlerV2(h ErrorHandlerV2) Option {
+	return func(e *AsyncError) {
+		e.handlerV2 = h
+	}
+}
+
+// WithErrorChanLen sets the chan length for the default severity
+// (SeverityError), the one used by Add/AddAsync. See WithSeverityCapacities
+// to size the other severities.
 func WithErrorChanLen(l int) Option {
 	return func(e *AsyncError) {
-		e.errorChan = make(chan error, l)
+		e.errorChans[defaultSeverity] = make(chan error, l)
+	}
+}
+
+// WithSeverityCapacities overrides the chan capacity for each given
+// severity; severities not present in caps keep the default capacity.
+func WithSeverityCapacities(caps map[Severity]int) Option {
+	return func(e *AsyncError) {
+		for s, l := range caps {
+			e.errorChans[s] = make(chan error, l)
+		}
+	}
+}
+
+// WithFatalHook registers a callback invoked synchronously whenever an
+// error is added at SeverityFatal, e.g. to flush logs and exit.
+func WithFatalHook(hook func(error)) Option {
+	return func(e *AsyncError) {
+		e.fatalHook = hook
+	}
+}
+
+// WithPanicStack toggles capturing a stack trace in the *PanicError
+// produced by PanicToError.
+func WithPanicStack(enabled bool) Option {
+	return func(e *AsyncError) {
+		e.panicStack = enabled
+	}
+}
+
+// WithDedup suppresses repeats of an error arriving within window, preventing
+// a flood of them from reaching the handler. The first occurrence is handled
+// as usual; if repeats arrived, a single *DuplicateError carrying the total
+// count is handled once the window elapses.
+// Errors are considered identical when keyFn returns the same key; if keyFn
+// is nil, err.Error() is used.
+func WithDedup(window time.Duration, keyFn func(error) string) Option {
+	if keyFn == nil {
+		keyFn = func(err error) string { return err.Error() }
+	}
+
+	return func(e *AsyncError) {
+		e.dedup = newDedupSuppressor(window, keyFn, func(summary error, s Severity) {
+			if !e.deliver(&LeveledError{Err: summary, Severity: s}, s) {
+				e.logger.Error(summary, "aerrors: dropped dedup summary for closed aerror")
+			}
+		})
 	}
 }
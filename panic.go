@@ -0,0 +1,40 @@
+package aerrors
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError wraps the value recovered from a panic together with the
+// goroutine's stack trace captured at the moment of recovery, so handlers
+// can log or ship it instead of losing it to a bare fmt.Errorf("%v", p).
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the recovered value when it is itself an error, so that
+// errors.Is/errors.As can keep walking the chain.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// panicError builds the wrapped error for a recovered panic value p. It is
+// shared by AsyncError.PanicToError and the package-level PanicToError so
+// both produce identical errors.
+func panicError(p any, captureStack bool) error {
+	pErr := &PanicError{Value: p}
+	if captureStack {
+		pErr.Stack = debug.Stack()
+	}
+
+	err := error(pErr)
+	Wrap(&err, "recoverToError()")
+
+	return err
+}
@@ -0,0 +1,63 @@
+package aerrors
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPanicToErrorCapturesStack(t *testing.T) {
+	aerror := New(WithHandler(&th), WithPanicStack(true))
+	aerror.StartHandle()
+	defer aerror.Stop()
+	defer th.Reset()
+
+	go func() {
+		defer aerror.PanicToError()
+		panic("test panic with stack")
+	}()
+	time.Sleep(sleepTime)
+
+	if len(th.errs) != 1 {
+		t.Fatal("expected to have an error")
+	}
+
+	var panicErr *PanicError
+	if !errors.As(th.errs[0], &panicErr) {
+		t.Fatal("expected error chain to contain a *PanicError")
+	}
+
+	if panicErr.Value != "test panic with stack" {
+		t.Error("expected recovered value to be preserved")
+	}
+
+	if len(panicErr.Stack) == 0 {
+		t.Error("expected a non-empty stack trace")
+	}
+}
+
+func TestPanicToErrorWithoutStack(t *testing.T) {
+	aerror := New(WithHandler(&th))
+	aerror.StartHandle()
+	defer aerror.Stop()
+	defer th.Reset()
+
+	go func() {
+		defer aerror.PanicToError()
+		panic("test panic without stack")
+	}()
+	time.Sleep(sleepTime)
+
+	if len(th.errs) != 1 {
+		t.Fatal("expected to have an error")
+	}
+
+	var panicErr *PanicError
+	if !errors.As(th.errs[0], &panicErr) {
+		t.Fatal("expected error chain to contain a *PanicError")
+	}
+
+	if len(panicErr.Stack) != 0 {
+		t.Error("expected no stack trace when WithPanicStack is not set")
+	}
+}
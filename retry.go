@@ -0,0 +1,122 @@
+package aerrors
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ErrorHandlerV2 is like ErrorHandler, but its HandleError may itself fail
+// (e.g. shipping to Sentry/HTTP) and reports that failure back to the
+// caller instead of it being silently swallowed.
+type ErrorHandlerV2 interface {
+	HandleError(ctx context.Context, err error) error
+}
+
+// AdaptErrorHandler wraps a legacy ErrorHandler so it can be used wherever
+// an ErrorHandlerV2 is expected, e.g. inside NewRetryHandler.
+func AdaptErrorHandler(h ErrorHandler) ErrorHandlerV2 {
+	return &errorHandlerV2Adapter{inner: h}
+}
+
+type errorHandlerV2Adapter struct {
+	inner ErrorHandler
+}
+
+func (a *errorHandlerV2Adapter) HandleError(_ context.Context, err error) error {
+	a.inner.HandleError(err)
+	return nil
+}
+
+// BackoffPolicy configures the exponential backoff with jitter used between
+// retry attempts.
+type BackoffPolicy struct {
+	InitialInterval time.Duration
+	Multiplier      float64
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// DefaultBackoffPolicy is a reasonable default for retrying flaky handlers.
+var DefaultBackoffPolicy = BackoffPolicy{
+	InitialInterval: 100 * time.Millisecond,
+	Multiplier:      2,
+	MaxInterval:     10 * time.Second,
+	MaxElapsedTime:  time.Minute,
+}
+
+func (b BackoffPolicy) nextInterval(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * b.Multiplier)
+	if b.MaxInterval > 0 && next > b.MaxInterval {
+		next = b.MaxInterval
+	}
+
+	return next
+}
+
+func (b BackoffPolicy) jitter(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(interval)))
+}
+
+// RetryHandler wraps an ErrorHandlerV2 and retries its HandleError with
+// exponential backoff and jitter while it returns a non-nil error. Once
+// MaxElapsedTime is exceeded, the failure and the number of attempts made
+// are forwarded to a fallback, which by default logs with DefaultLogger.
+type RetryHandler struct {
+	inner    ErrorHandlerV2
+	backoff  BackoffPolicy
+	fallback func(err error, attempts int)
+}
+
+// NewRetryHandler returns a *RetryHandler wrapping inner with the given
+// backoff policy.
+func NewRetryHandler(inner ErrorHandlerV2, b BackoffPolicy) *RetryHandler {
+	return &RetryHandler{
+		inner:   inner,
+		backoff: b,
+		fallback: func(err error, attempts int) {
+			DefaultLogger.Error(err, fmt.Sprintf("aerrors: handler failed permanently after %d attempts", attempts))
+		},
+	}
+}
+
+// WithFallback overrides what happens once retries are exhausted.
+func (r *RetryHandler) WithFallback(fallback func(err error, attempts int)) *RetryHandler {
+	r.fallback = fallback
+	return r
+}
+
+// HandleError retries inner.HandleError until it succeeds, ctx is done, or
+// MaxElapsedTime elapses, whichever comes first.
+func (r *RetryHandler) HandleError(ctx context.Context, err error) error {
+	interval := r.backoff.InitialInterval
+	start := time.Now()
+	attempts := 0
+
+	for {
+		attempts++
+		handleErr := r.inner.HandleError(ctx, err)
+		if handleErr == nil {
+			return nil
+		}
+
+		if r.backoff.MaxElapsedTime > 0 && time.Since(start) >= r.backoff.MaxElapsedTime {
+			r.fallback(handleErr, attempts)
+			return handleErr
+		}
+
+		select {
+		case <-ctx.Done():
+			r.fallback(ctx.Err(), attempts)
+			return ctx.Err()
+		case <-time.After(r.backoff.jitter(interval)):
+		}
+
+		interval = r.backoff.nextInterval(interval)
+	}
+}
@@ -0,0 +1,84 @@
+package aerrors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type flakyHandler struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (f *flakyHandler) HandleError(_ context.Context, err error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("temporary failure")
+	}
+
+	return nil
+}
+
+func TestRetryHandlerSucceedsAfterFailures(t *testing.T) {
+	inner := &flakyHandler{failures: 2}
+	retry := NewRetryHandler(inner, BackoffPolicy{
+		InitialInterval: time.Millisecond,
+		Multiplier:      2,
+		MaxInterval:     10 * time.Millisecond,
+		MaxElapsedTime:  time.Second,
+	})
+
+	if err := retry.HandleError(context.Background(), errors.New("boom")); err != nil {
+		t.Fatalf("expected retry to eventually succeed, got %v", err)
+	}
+
+	if inner.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", inner.calls)
+	}
+}
+
+func TestRetryHandlerFallsBackAfterMaxElapsedTime(t *testing.T) {
+	inner := &flakyHandler{failures: 1000}
+	var fallbackErr error
+	var fallbackAttempts int
+
+	retry := NewRetryHandler(inner, BackoffPolicy{
+		InitialInterval: time.Millisecond,
+		Multiplier:      2,
+		MaxInterval:     2 * time.Millisecond,
+		MaxElapsedTime:  5 * time.Millisecond,
+	}).WithFallback(func(err error, attempts int) {
+		fallbackErr = err
+		fallbackAttempts = attempts
+	})
+
+	err := retry.HandleError(context.Background(), errors.New("boom"))
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+
+	if fallbackErr == nil || fallbackAttempts == 0 {
+		t.Error("expected the fallback to be invoked with a non-zero attempt count")
+	}
+}
+
+func TestAsyncErrorUsesHandlerV2(t *testing.T) {
+	inner := &flakyHandler{}
+	aerror := New(WithHandlerV2(inner))
+	aerror.StartHandle()
+	defer aerror.Stop()
+
+	aerror.Add(errors.New("testing v2 handler"))
+	time.Sleep(sleepTime)
+
+	if inner.calls != 1 {
+		t.Errorf("expected the v2 handler to be invoked once, got %d", inner.calls)
+	}
+}
@@ -0,0 +1,113 @@
+package aerrors
+
+import "errors"
+
+// Severity indicates how urgently an added error needs attention.
+type Severity int
+
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// defaultSeverity is used by Add/AddAsync so the pre-existing API keeps
+// working unchanged.
+const defaultSeverity = SeverityError
+
+// severityOrder lists every severity from highest to lowest priority.
+// dequeue scans channels in this order so a backlog of low-severity errors
+// never delays a higher-severity one.
+var severityOrder = []Severity{SeverityFatal, SeverityError, SeverityWarn, SeverityInfo, SeverityDebug}
+
+func newSeverityChans(capacity int) map[Severity]chan error {
+	chans := make(map[Severity]chan error, len(severityOrder))
+	for _, s := range severityOrder {
+		chans[s] = make(chan error, capacity)
+	}
+
+	return chans
+}
+
+// LeveledError wraps an error with the Severity it was added at, so the
+// level survives the trip through the queue and can be recovered by
+// handlers via errors.As.
+type LeveledError struct {
+	Err      error
+	Severity Severity
+}
+
+func (e *LeveledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LeveledError) Unwrap() error {
+	return e.Err
+}
+
+// AddWithSeverity puts err in the queue for the given severity. It blocks
+// if that severity's chan has reached capacity.
+func (e *AsyncError) AddWithSeverity(err error, s Severity) error {
+	if e.IsClosed() {
+
+		return errors.New("aerrors: can't add error to closed chan")
+	}
+
+	if e.dedup != nil && !e.dedup.shouldEnqueue(err, s) {
+		return nil
+	}
+
+	if s == SeverityFatal && e.fatalHook != nil {
+		e.fatalHook(err)
+	}
+
+	if !e.deliver(&LeveledError{Err: err, Severity: s}, s) {
+		return errors.New("aerrors: can't add error to closed chan")
+	}
+
+	return nil
+}
+
+// AddAsyncWithSeverity puts err in the queue for the given severity from a
+// goroutine, so it doesn't block when that severity's chan is full.
+func (e *AsyncError) AddAsyncWithSeverity(err error, s Severity) error {
+	if e.IsClosed() {
+
+		return errors.New("aerrors: can't async add error to closed chan")
+	}
+
+	if e.dedup != nil && !e.dedup.shouldEnqueue(err, s) {
+		return nil
+	}
+
+	if s == SeverityFatal && e.fatalHook != nil {
+		e.fatalHook(err)
+	}
+
+	go func() {
+		if !e.deliver(&LeveledError{Err: err, Severity: s}, s) {
+			e.logger.Error(err, "aerrors: dropped async-added error for closed aerror")
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package aerrors
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHighSeverityHandledBeforeBacklog(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	handler := ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, err.Error())
+	})
+
+	aerror := New(WithHandler(handler), WithSeverityCapacities(map[Severity]int{
+		SeverityDebug: 10,
+		SeverityFatal: 10,
+	}))
+
+	for i := 0; i < 5; i++ {
+		aerror.AddWithSeverity(errors.New("debug"), SeverityDebug)
+	}
+	aerror.AddWithSeverity(errors.New("fatal"), SeverityFatal)
+
+	aerror.StartHandle()
+	defer aerror.Stop()
+	time.Sleep(sleepTime)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) == 0 || !strings.HasSuffix(order[0], "fatal") {
+		t.Fatalf("expected the fatal error to be handled first, got %v", order)
+	}
+}
+
+// TestHighSeverityHandledBeforeBacklogConcurrent stresses dequeue() with
+// both a Fatal and a Debug error already buffered before consumption starts
+// for each round, to catch the inversion a single-goroutine test like
+// TestHighSeverityHandledBeforeBacklog can't reach: a blocking select that
+// consumes directly from the errorChans picks uniformly among whatever
+// cases are ready, so it can hand a goroutine the debug error even though
+// the fatal one was also ready. Each round stops handling, concurrently
+// enqueues both (so ready order isn't influenced by a live consumer),
+// waits for both sends to land, then restarts handling and checks the pair
+// came out fatal-first.
+func TestHighSeverityHandledBeforeBacklogConcurrent(t *testing.T) {
+	const rounds = 300
+
+	var mu sync.Mutex
+	var order []Severity
+
+	handler := ErrorHandlerFunc(func(err error) {
+		var leveled *LeveledError
+		if !errors.As(err, &leveled) {
+			t.Fatalf("expected a *LeveledError, got %T", err)
+		}
+		mu.Lock()
+		order = append(order, leveled.Severity)
+		mu.Unlock()
+	})
+
+	aerror := New(WithHandler(handler))
+	defer aerror.Stop()
+
+	for i := 0; i < rounds; i++ {
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-release
+			aerror.AddWithSeverity(errors.New("fatal"), SeverityFatal)
+		}()
+		go func() {
+			defer wg.Done()
+			<-release
+			aerror.AddWithSeverity(errors.New("debug"), SeverityDebug)
+		}()
+		close(release)
+		wg.Wait()
+
+		aerror.StartHandle()
+
+		want := 2 * (i + 1)
+		deadline := time.Now().Add(time.Second)
+		for {
+			mu.Lock()
+			n := len(order)
+			mu.Unlock()
+			if n == want {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("round %d: expected %d handled errors, got %d", i, want, n)
+			}
+			time.Sleep(sleepTime)
+		}
+		aerror.Stop()
+
+		mu.Lock()
+		got := append([]Severity(nil), order[2*i:2*i+2]...)
+		mu.Unlock()
+		if got[0] != SeverityFatal || got[1] != SeverityDebug {
+			t.Fatalf("round %d: expected [fatal debug], got %v", i, got)
+		}
+	}
+}
+
+func TestAddWithSeverityWrapsLeveledError(t *testing.T) {
+	aerror := New(WithHandler(&th))
+	aerror.StartHandle()
+	defer aerror.Stop()
+	defer th.Reset()
+
+	aerror.AddWithSeverity(errors.New("warn level"), SeverityWarn)
+	time.Sleep(sleepTime)
+
+	if len(th.errs) != 1 {
+		t.Fatalf("expected one handled error, got %d", len(th.errs))
+	}
+
+	var leveled *LeveledError
+	if !errors.As(th.errs[0], &leveled) || leveled.Severity != SeverityWarn {
+		t.Errorf("expected a *LeveledError at SeverityWarn, got %v", th.errs[0])
+	}
+}
+
+func TestFatalHookFiresOnFatalSeverity(t *testing.T) {
+	var hookCalls int
+	var mu sync.Mutex
+
+	aerror := New(WithHandler(&th), WithFatalHook(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		hookCalls++
+	}))
+	defer th.Reset()
+
+	aerror.AddWithSeverity(errors.New("fatal condition"), SeverityFatal)
+	aerror.AddWithSeverity(errors.New("just a warning"), SeverityWarn)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if hookCalls != 1 {
+		t.Errorf("expected the fatal hook to fire exactly once, got %d", hookCalls)
+	}
+}
+
+// ErrorHandlerFunc adapts a plain function to ErrorHandler, mirroring
+// http.HandlerFunc, for tests that only need a stateless callback.
+type ErrorHandlerFunc func(err error)
+
+func (f ErrorHandlerFunc) HandleError(err error) {
+	f(err)
+}